@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/willhaben-vip/server/pkg/tmplvalidate"
+)
+
+func main() {
+	// Parse command line arguments
+	fileName := flag.String("file", "slack.tmpl", "Template file to validate")
+	configPath := flag.String("config", "", "Alertmanager config to validate (e.g. alertmanager.yml)")
+	templateGlob := flag.String("templates", "", "Glob of template files the config's templates: section loads (e.g. 'templates/*.tmpl')")
+	render := flag.Bool("render", false, "Execute every defined template against sample payloads and diff against golden files")
+	update := flag.Bool("update", false, "With -render, (re)write golden files instead of failing on a mismatch")
+	samplesPath := flag.String("samples", "", "JSON file of sample template.Data payloads for -render (defaults to a built-in library)")
+	goldenDir := flag.String("golden", "testdata/golden", "Directory of golden files for -render")
+	serve := flag.Bool("serve", false, "Run as an HTTP daemon exposing POST /validate, a preview UI at /, and /metrics")
+	addr := flag.String("addr", ":8080", "Address to listen on with -serve")
+	flag.Parse()
+
+	if *serve {
+		server := tmplvalidate.NewServer()
+		fmt.Printf("tmplvalidate serving on %s\n", *addr)
+		if err := server.ListenAndServe(*addr); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *render {
+		failed, err := tmplvalidate.RunRender(*fileName, *samplesPath, *goldenDir, *update)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if failed {
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *configPath != "" {
+		validator, err := tmplvalidate.NewConfigValidator(*configPath, []string{*templateGlob})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		isValid := validator.Validate()
+		validator.PrintResults()
+
+		if !isValid {
+			os.Exit(1)
+		}
+		return
+	}
+
+	validator, err := tmplvalidate.NewTemplateValidator(*fileName)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	isValid := validator.Validate()
+	validator.PrintResults()
+
+	if !isValid {
+		os.Exit(1)
+	}
+}
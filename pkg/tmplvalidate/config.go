@@ -0,0 +1,225 @@
+package tmplvalidate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// templateNameRegex matches a `{{template "name" ...}}` call so the name
+// can be pulled out of a notifier field's template text.
+func templateNameRegex() *regexp.Regexp {
+	return regexp.MustCompile(`{{-?\s*template\s+"([^"]+)"`)
+}
+
+// AlertmanagerConfig is the subset of alertmanager.yml this tool cares
+// about: the receivers and the notifier configs within them that carry a
+// template reference (title/text/html/description fields rendered through
+// the Go template engine).
+type AlertmanagerConfig struct {
+	Receivers []ReceiverConfig `yaml:"receivers"`
+}
+
+// ReceiverConfig is one entry under `receivers:` in alertmanager.yml.
+type ReceiverConfig struct {
+	Name             string                   `yaml:"name"`
+	SlackConfigs     []map[string]interface{} `yaml:"slack_configs"`
+	EmailConfigs     []map[string]interface{} `yaml:"email_configs"`
+	PagerdutyConfigs []map[string]interface{} `yaml:"pagerduty_configs"`
+}
+
+// templateFields lists, for each notifier type, which YAML keys hold a
+// Go template string rather than a literal value.
+var templateFields = map[string][]string{
+	"slack_configs":     {"title", "text", "pretext", "footer"},
+	"email_configs":     {"html", "text"},
+	"pagerduty_configs": {"description"},
+}
+
+// templateRef is one notifier field found to contain a {{template "name" .}}
+// call, kept alongside where it came from for error reporting.
+type templateRef struct {
+	receiver string
+	notifier string
+	field    string
+	name     string
+}
+
+// LoadAlertmanagerConfig reads and parses an alertmanager.yml file.
+func LoadAlertmanagerConfig(path string) (*AlertmanagerConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config %s: %v", path, err)
+	}
+
+	var cfg AlertmanagerConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("error parsing config %s: %v", path, err)
+	}
+	return &cfg, nil
+}
+
+// templateReferences walks every receiver's notifier configs and collects
+// the template names referenced via `{{template "name" .}}` in any of the
+// known template fields for that notifier type.
+func (cfg *AlertmanagerConfig) templateReferences() []templateRef {
+	nameRegex := templateNameRegex()
+	var refs []templateRef
+
+	collect := func(receiver, notifier string, configs []map[string]interface{}) {
+		fields := templateFields[notifier]
+		for _, config := range configs {
+			for _, field := range fields {
+				raw, ok := config[field]
+				if !ok {
+					continue
+				}
+				text, ok := raw.(string)
+				if !ok {
+					continue
+				}
+				for _, match := range nameRegex.FindAllStringSubmatch(text, -1) {
+					refs = append(refs, templateRef{
+						receiver: receiver,
+						notifier: notifier,
+						field:    field,
+						name:     match[1],
+					})
+				}
+			}
+		}
+	}
+
+	for _, r := range cfg.Receivers {
+		collect(r.Name, "slack_configs", r.SlackConfigs)
+		collect(r.Name, "email_configs", r.EmailConfigs)
+		collect(r.Name, "pagerduty_configs", r.PagerdutyConfigs)
+	}
+	return refs
+}
+
+// ConfigValidator validates an alertmanager.yml against a set of template
+// files, checking that every template a receiver references actually
+// resolves and reporting templates that are defined but never used.
+type ConfigValidator struct {
+	ConfigPath    string
+	TemplateGlobs []string
+	Errors        []string
+	Warnings      []string
+
+	cfg  *AlertmanagerConfig
+	tmpl *template.Template
+	// fileNames holds the basename ParseFiles gave each loaded file's
+	// own template (distinct from any {{define}} blocks inside it), so
+	// Validate can exclude those from the defined-templates set.
+	fileNames map[string]bool
+}
+
+// NewConfigValidator loads the config and parses every template file
+// matched by the given globs into a single *template.Template set so
+// cross-file {{template "x" .}} calls resolve the same way Alertmanager
+// itself resolves them at startup.
+func NewConfigValidator(configPath string, templateGlobs []string) (*ConfigValidator, error) {
+	cfg, err := LoadAlertmanagerConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	root := template.New("").Funcs(alertmanagerFuncs)
+	var files []string
+	for _, glob := range templateGlobs {
+		matches, err := filepath.Glob(glob)
+		if err != nil {
+			return nil, fmt.Errorf("error expanding template glob %q: %v", glob, err)
+		}
+		files = append(files, matches...)
+	}
+	if len(files) > 0 {
+		var err error
+		root, err = root.ParseFiles(files...)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing templates: %v", err)
+		}
+	}
+
+	fileNames := make(map[string]bool, len(files))
+	for _, f := range files {
+		fileNames[filepath.Base(f)] = true
+	}
+
+	return &ConfigValidator{
+		ConfigPath:    configPath,
+		TemplateGlobs: templateGlobs,
+		cfg:           cfg,
+		tmpl:          root,
+		fileNames:     fileNames,
+	}, nil
+}
+
+// Validate checks that every template referenced by a receiver resolves to
+// a loaded {{define}} block and warns about templates that are defined but
+// never referenced by any receiver. It returns false if any reference is
+// unresolved.
+func (cv *ConfigValidator) Validate() bool {
+	defined := map[string]bool{}
+	for _, t := range cv.tmpl.Templates() {
+		if t.Tree == nil || t.Tree.Root == nil || t.Name() == "" {
+			continue
+		}
+		// ParseFiles names a template after each file's own basename in
+		// addition to any {{define}} blocks it contains; that artifact
+		// is never something a receiver references by name, so it
+		// would otherwise always show up as "defined but never used".
+		if cv.fileNames[t.Name()] {
+			continue
+		}
+		defined[t.Name()] = true
+	}
+
+	referenced := map[string]bool{}
+	for _, ref := range cv.cfg.templateReferences() {
+		referenced[ref.name] = true
+		if !defined[ref.name] {
+			cv.Errors = append(cv.Errors, fmt.Sprintf(
+				"receiver %q: %s.%s references undefined template %q",
+				ref.receiver, ref.notifier, ref.field, ref.name))
+		}
+	}
+
+	for name := range defined {
+		if !referenced[name] {
+			cv.Warnings = append(cv.Warnings, fmt.Sprintf("template %q is defined but never referenced by a receiver", name))
+		}
+	}
+
+	return len(cv.Errors) == 0
+}
+
+// PrintResults prints the config validation results in the same style as
+// TemplateValidator.PrintResults.
+func (cv *ConfigValidator) PrintResults() {
+	if len(cv.Errors) == 0 && len(cv.Warnings) == 0 {
+		fmt.Printf("✅ Config %s and its templates are consistent.\n", cv.ConfigPath)
+		return
+	}
+
+	fmt.Printf("Results for %s:\n", cv.ConfigPath)
+
+	if len(cv.Errors) > 0 {
+		fmt.Println("\n❌ ERRORS:")
+		for i, err := range cv.Errors {
+			fmt.Printf("%d. %s\n", i+1, err)
+		}
+	}
+
+	if len(cv.Warnings) > 0 {
+		fmt.Println("\n⚠️  WARNINGS:")
+		for i, warning := range cv.Warnings {
+			fmt.Printf("%d. %s\n", i+1, warning)
+		}
+	}
+}
@@ -0,0 +1,236 @@
+package tmplvalidate
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// execFuncs is the real implementation of the Alertmanager function
+// catalog alertmanagerFuncs only stubs out. validateTemplateSyntax parses
+// with the stub map so a real template's use of these names doesn't fail
+// to parse; Render and RenderSample layer execFuncs on top of the already
+// parsed template before executing it - text/template resolves a function
+// call by name against whatever FuncMap is current at execution time, so
+// this works without re-parsing.
+var execFuncs = template.FuncMap{
+	"toUpper":   strings.ToUpper,
+	"toLower":   strings.ToLower,
+	"title":     strings.Title,
+	"trimSpace": strings.TrimSpace,
+	"match":     func(pattern, s string) (bool, error) { return regexp.MatchString(pattern, s) },
+	"reReplaceAll": func(pattern, repl, text string) string {
+		return regexp.MustCompile(pattern).ReplaceAllString(text, repl)
+	},
+	"safeHtml":           func(text string) string { return text },
+	"stringSlice":        func(s ...string) []string { return s },
+	"join":               func(sep string, s []string) string { return strings.Join(s, sep) },
+	"sortByLabel":        sortByLabel,
+	"humanize":           humanize,
+	"humanize1024":       humanize1024,
+	"humanizeDuration":   humanizeDuration,
+	"humanizePercentage": humanizePercentage,
+	"humanizeTimestamp":  humanizeTimestamp,
+	"urlquery":           url.QueryEscape,
+	"trunc":              trunc,
+	"date":               date,
+	"default":            defaultValue,
+	"printf":             fmt.Sprintf,
+}
+
+// sortByLabel sorts an Alerts slice by the value of the given label,
+// mirroring Alertmanager's template helper of the same name. v is
+// returned unchanged if it isn't an Alerts slice.
+func sortByLabel(label string, v interface{}) interface{} {
+	alerts, ok := v.(Alerts)
+	if !ok {
+		return v
+	}
+	sorted := make(Alerts, len(alerts))
+	copy(sorted, alerts)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Labels[label] < sorted[j].Labels[label]
+	})
+	return sorted
+}
+
+// convertToFloat coerces a template value - typically a label or
+// annotation string - to a float64 for the humanize* helpers below.
+func convertToFloat(i interface{}) (float64, error) {
+	switch v := i.(type) {
+	case float64:
+		return v, nil
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("can't convert %T to float", v)
+	}
+}
+
+// humanize formats v with an SI magnitude suffix (k, M, G, ... or m, u,
+// n, ... for values below 1), e.g. "1.234k".
+func humanize(i interface{}) (string, error) {
+	v, err := convertToFloat(i)
+	if err != nil {
+		return "", err
+	}
+	if v == 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Sprintf("%.4g", v), nil
+	}
+	if math.Abs(v) >= 1 {
+		prefix := ""
+		for _, p := range []string{"k", "M", "G", "T", "P", "E", "Z", "Y"} {
+			if math.Abs(v) < 1000 {
+				break
+			}
+			prefix = p
+			v /= 1000
+		}
+		return fmt.Sprintf("%.4g%s", v, prefix), nil
+	}
+	prefix := ""
+	for _, p := range []string{"m", "u", "n", "p", "f", "a", "z", "y"} {
+		if math.Abs(v) >= 1 {
+			break
+		}
+		prefix = p
+		v *= 1000
+	}
+	return fmt.Sprintf("%.4g%s", v, prefix), nil
+}
+
+// humanize1024 is humanize's base-1024 counterpart, e.g. "1.234ki".
+func humanize1024(i interface{}) (string, error) {
+	v, err := convertToFloat(i)
+	if err != nil {
+		return "", err
+	}
+	if math.Abs(v) <= 1 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Sprintf("%.4g", v), nil
+	}
+	prefix := ""
+	for _, p := range []string{"ki", "Mi", "Gi", "Ti", "Pi", "Ei", "Zi", "Yi"} {
+		if math.Abs(v) < 1024 {
+			break
+		}
+		prefix = p
+		v /= 1024
+	}
+	return fmt.Sprintf("%.4g%s", v, prefix), nil
+}
+
+// humanizeDuration formats v, a number of seconds, as a "1d 2h 3m 4s"
+// style duration string.
+func humanizeDuration(i interface{}) (string, error) {
+	v, err := convertToFloat(i)
+	if err != nil {
+		return "", err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) || math.Abs(v) < 1 {
+		return fmt.Sprintf("%.4gs", v), nil
+	}
+
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+
+	seconds := int64(v) % 60
+	minutes := (int64(v) / 60) % 60
+	hours := (int64(v) / 60 / 60) % 24
+	days := int64(v) / 60 / 60 / 24
+
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%s%dd %dh %dm %ds", sign, days, hours, minutes, seconds), nil
+	case hours > 0:
+		return fmt.Sprintf("%s%dh %dm %ds", sign, hours, minutes, seconds), nil
+	case minutes > 0:
+		return fmt.Sprintf("%s%dm %ds", sign, minutes, seconds), nil
+	default:
+		return fmt.Sprintf("%s%ds", sign, seconds), nil
+	}
+}
+
+// humanizePercentage formats v, a fraction such as 0.5, as a percentage.
+func humanizePercentage(i interface{}) (string, error) {
+	v, err := convertToFloat(i)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%.4g%%", v*100), nil
+}
+
+// humanizeTimestamp formats v, a Unix timestamp in seconds, as an RFC3339
+// string in UTC.
+func humanizeTimestamp(i interface{}) (string, error) {
+	v, err := convertToFloat(i)
+	if err != nil {
+		return "", err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Sprintf("%.4g", v), nil
+	}
+	return time.Unix(int64(v), 0).UTC().Format(time.RFC3339), nil
+}
+
+// trunc truncates s to n bytes, or to the last -n bytes if n is negative,
+// mirroring Alertmanager's trunc helper.
+func trunc(n int, s string) string {
+	switch {
+	case n < 0 && -n < len(s):
+		return s[len(s)+n:]
+	case n >= 0 && n < len(s):
+		return s[:n]
+	default:
+		return s
+	}
+}
+
+// date formats v using layout. v may be a time.Time, an RFC3339 string, or
+// a Unix timestamp (as a string or number).
+func date(layout string, v interface{}) string {
+	switch t := v.(type) {
+	case time.Time:
+		return t.Format(layout)
+	case string:
+		if parsed, err := time.Parse(time.RFC3339, t); err == nil {
+			return parsed.Format(layout)
+		}
+		if f, err := strconv.ParseFloat(t, 64); err == nil {
+			return time.Unix(int64(f), 0).UTC().Format(layout)
+		}
+		return t
+	case float64:
+		return time.Unix(int64(t), 0).UTC().Format(layout)
+	case int64:
+		return time.Unix(t, 0).UTC().Format(layout)
+	case int:
+		return time.Unix(int64(t), 0).UTC().Format(layout)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// defaultValue returns val unless it is nil or an empty string, in which
+// case it returns def - the same fallback sprig's "default" provides.
+func defaultValue(def, val interface{}) interface{} {
+	if val == nil {
+		return def
+	}
+	if s, ok := val.(string); ok && s == "" {
+		return def
+	}
+	return val
+}
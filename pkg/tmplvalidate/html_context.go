@@ -0,0 +1,215 @@
+package tmplvalidate
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template/parse"
+
+	htmlescape "github.com/willhaben-vip/server/pkg/tmplvalidate/internal/htmltemplate"
+)
+
+// htmlAnalyzerFuncs mirrors alertmanagerFuncs, except safeHtml returns the
+// vendored package's HTML content type instead of a bare string - matching
+// Alertmanager's real safeHtml, which returns template.HTML precisely so
+// html/template treats its output as already escaped when executed. The
+// analyzer below does not execute with data; it recognises safeHtml by
+// name through explicitEscapeFuncs.
+var htmlAnalyzerFuncs = htmlescape.FuncMap{
+	"toUpper":            func(string) string { return "" },
+	"toLower":            func(string) string { return "" },
+	"title":              func(string) string { return "" },
+	"trimSpace":          func(string) string { return "" },
+	"match":              func(string, string) (bool, error) { return false, nil },
+	"reReplaceAll":       func(string, string, string) string { return "" },
+	"safeHtml":           func(text string) htmlescape.HTML { return htmlescape.HTML(text) },
+	"stringSlice":        func(...string) []string { return nil },
+	"join":               func(string, []string) string { return "" },
+	"sortByLabel":        func(string, interface{}) interface{} { return nil },
+	"humanize":           func(interface{}) (string, error) { return "", nil },
+	"humanize1024":       func(interface{}) (string, error) { return "", nil },
+	"humanizeDuration":   func(interface{}) (string, error) { return "", nil },
+	"humanizePercentage": func(interface{}) (string, error) { return "", nil },
+	"humanizeTimestamp":  func(interface{}) (string, error) { return "", nil },
+	// urlquery and printf are builtins of the escaper's template package.
+	"trunc":   func(int, string) string { return "" },
+	"date":    func(string, interface{}) string { return "" },
+	"default": func(interface{}, interface{}) interface{} { return nil },
+}
+
+// contextEscapers maps the name of an escaper function html/template's
+// escaping pass injects into a pipeline to a human-readable description of
+// the context that triggered it. Only URL/JS/CSS contexts are listed:
+// _html_template_htmlescaper is the expected, safe outcome for plain HTML
+// body/attribute text and isn't worth a warning on its own.
+var contextEscapers = map[string]string{
+	"_html_template_urlescaper":      "URL",
+	"_html_template_urlnormalizer":   "URL",
+	"_html_template_urlfilter":       "URL",
+	"_html_template_jsstrescaper":    "JS string",
+	"_html_template_jsvalescaper":    "JS value",
+	"_html_template_jsregexpescaper": "JS regexp",
+	"_html_template_cssescaper":      "CSS",
+	"_html_template_cssvaluefilter":  "CSS",
+}
+
+// explicitEscapeFuncs are the pipeline functions html/template's escaper
+// treats as already producing safe output for the context they're used
+// in, so a pipeline ending in one of these is never flagged below even if
+// it lands in a URL/JS/CSS context.
+var explicitEscapeFuncs = map[string]bool{
+	"safeHtml": true,
+	"html":     true,
+	"urlquery": true,
+}
+
+// HTMLContextAnalyzer runs a template body through a vendored copy of Go's
+// html/template escaper (pkg/tmplvalidate/internal/htmltemplate, forked
+// from the standard library the same way Hugo forks it in
+// tpl/internal/go_templates/htmltemplate) and reports, for each pipeline,
+// the contextual escaper the real html/template engine would inject -
+// _html_template_urlescaper, _html_template_jsstrescaper, and so on -
+// rather than re-deriving markup context by hand.
+type HTMLContextAnalyzer struct {
+	Errors   []string
+	Warnings []string
+}
+
+// IsHTMLTemplate reports whether name/content look like an HTML template
+// (Slack uses text templates, email notifications use HTML ones) based on
+// the file extension or, for a {{define}} block with no file extension of
+// its own, whether the body contains an <html> or <body> tag.
+func IsHTMLTemplate(name, content string) bool {
+	lower := strings.ToLower(name)
+	if strings.HasSuffix(lower, ".html") || strings.HasSuffix(lower, ".tmpl.html") {
+		return true
+	}
+	lowerContent := strings.ToLower(content)
+	return strings.Contains(lowerContent, "<html") || strings.Contains(lowerContent, "<body")
+}
+
+// Analyze parses name/content with the vendored html/template escaper and
+// records, per pipeline, which contextual escaper it injected. A pipeline
+// that lands in a URL/JS/CSS context is reported as a Warning unless the
+// template already pipes it through safeHtml or the builtin "html"/
+// "urlquery" escapers, which the real escaper treats as already applied.
+// A template the escaper can't make safe at all (for example one that
+// ends mid-tag) is recorded as an Error.
+func (a *HTMLContextAnalyzer) Analyze(name, content string) {
+	tmpl, err := htmlescape.New(name).Funcs(htmlAnalyzerFuncs).Parse(content)
+	if err != nil {
+		a.Errors = append(a.Errors, fmt.Sprintf("HTML template error: %v", err))
+		return
+	}
+
+	// ExecuteTemplate triggers escaping of that named template (and
+	// anything it {{template}}s into) as a side effect before it touches
+	// the nil data, so every {{define}} block - not just the implicit root
+	// - gets escaped. A genuine escaping failure surfaces as
+	// *htmlescape.Error; the "can't evaluate field ... in <nil>" execution
+	// error and "is an incomplete template" lookup error that can follow
+	// are irrelevant here and are discarded.
+	for _, t := range tmpl.Templates() {
+		if execErr := tmpl.ExecuteTemplate(io.Discard, t.Name(), nil); execErr != nil {
+			if escErr, ok := execErr.(*htmlescape.Error); ok {
+				a.Errors = append(a.Errors, fmt.Sprintf("HTML escaping error in %q: %v", t.Name(), escErr))
+			}
+		}
+	}
+
+	for _, t := range tmpl.Templates() {
+		if t.Tree == nil || t.Tree.Root == nil {
+			continue
+		}
+		a.walk(t.Tree, t.Tree.Root)
+	}
+}
+
+// walk recurses through the escaped parse tree looking for pipelines the
+// escaper annotated with a URL/JS/CSS escaper.
+func (a *HTMLContextAnalyzer) walk(tree *parse.Tree, node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			a.walk(tree, child)
+		}
+	case *parse.ActionNode:
+		a.checkPipe(tree, n.Pipe)
+	case *parse.TemplateNode:
+		a.checkPipe(tree, n.Pipe)
+	case *parse.IfNode:
+		a.walk(tree, n.List)
+		a.walk(tree, n.ElseList)
+	case *parse.RangeNode:
+		a.walk(tree, n.List)
+		a.walk(tree, n.ElseList)
+	case *parse.WithNode:
+		a.walk(tree, n.List)
+		a.walk(tree, n.ElseList)
+	}
+}
+
+// checkPipe inspects every command the escaper left in pipe, warning about
+// URL/JS/CSS escapers it injected unless the pipeline already called an
+// explicitEscapeFuncs entry, and separately flagging a raw .Alerts field
+// reaching a URL/JS/CSS context - dumping the whole alert struct into a
+// <script> block or an href is an XSS risk no per-value escaper fixes.
+func (a *HTMLContextAnalyzer) checkPipe(tree *parse.Tree, pipe *parse.PipeNode) {
+	if pipe == nil {
+		return
+	}
+
+	explicit := false
+	var kinds []string
+	rawAlerts := false
+	for _, cmd := range pipe.Cmds {
+		if len(cmd.Args) == 0 {
+			continue
+		}
+		switch arg := cmd.Args[0].(type) {
+		case *parse.IdentifierNode:
+			if explicitEscapeFuncs[arg.Ident] {
+				explicit = true
+			}
+			if kind, tracked := contextEscapers[arg.Ident]; tracked {
+				kinds = append(kinds, fmt.Sprintf("%s (%s)", kind, arg.Ident))
+			}
+		case *parse.FieldNode:
+			if len(arg.Ident) > 0 && arg.Ident[0] == "Alerts" {
+				rawAlerts = true
+			}
+		}
+	}
+	if len(kinds) == 0 {
+		return
+	}
+
+	location, context := tree.ErrorContext(pipe)
+	if rawAlerts && !explicit {
+		a.Warnings = append(a.Warnings, fmt.Sprintf(
+			"%s: raw .Alerts reference reaches a %s context, an XSS risk: %s",
+			location, strings.Join(kinds, ", "), context))
+		return
+	}
+	if !explicit {
+		a.Warnings = append(a.Warnings, fmt.Sprintf(
+			"%s: pipeline in a %s context is missing an explicit escape: %s",
+			location, strings.Join(kinds, ", "), context))
+	}
+}
+
+// validateHTMLContext runs the HTMLContextAnalyzer over v.Content when the
+// file looks like an HTML template and folds its findings into Errors and
+// Warnings.
+func (v *TemplateValidator) validateHTMLContext() {
+	if !IsHTMLTemplate(v.FileName, v.Content) {
+		return
+	}
+	analyzer := &HTMLContextAnalyzer{}
+	analyzer.Analyze(v.FileName, v.Content)
+	v.Errors = append(v.Errors, analyzer.Errors...)
+	v.Warnings = append(v.Warnings, analyzer.Warnings...)
+}
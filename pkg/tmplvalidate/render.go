@@ -0,0 +1,292 @@
+package tmplvalidate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Alert mirrors the fields of an individual alert in Alertmanager's
+// template.Data payload (github.com/prometheus/alertmanager/template).
+// It is redeclared here rather than imported so -render can build sample
+// payloads without pulling in the Alertmanager server as a dependency.
+type Alert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     string            `json:"startsAt"`
+	EndsAt       string            `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// Alerts is the slice of alerts in a payload, with the same Firing/Resolved
+// helper methods Alertmanager's template.Data.Alerts exposes so templates
+// that call `.Alerts.Firing` render the same way here as in production.
+type Alerts []Alert
+
+// Firing returns the alerts whose status is "firing".
+func (as Alerts) Firing() Alerts {
+	return as.filterByStatus("firing")
+}
+
+// Resolved returns the alerts whose status is "resolved".
+func (as Alerts) Resolved() Alerts {
+	return as.filterByStatus("resolved")
+}
+
+func (as Alerts) filterByStatus(status string) Alerts {
+	var out Alerts
+	for _, a := range as {
+		if a.Status == status {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// SamplePayload is the shape of one entry in the -samples JSON file: the
+// fields of Alertmanager's template.Data plus a Case name used to build
+// the golden file's filename.
+type SamplePayload struct {
+	Case              string            `json:"case"`
+	Receiver          string            `json:"receiver"`
+	Status            string            `json:"status"`
+	Alerts            Alerts            `json:"alerts"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+}
+
+// builtinPayloads is the small library of synthetic payloads shipped so
+// `-render` is useful without the caller writing fixtures: a single firing
+// alert, a grouped firing alert, a resolved alert, and an alert missing a
+// "summary" annotation to exercise templates that assume it's always set.
+func builtinPayloads() []SamplePayload {
+	return []SamplePayload{
+		{
+			Case:     "firing_single",
+			Receiver: "team-pager",
+			Status:   "firing",
+			Alerts: Alerts{
+				{
+					Status:      "firing",
+					Labels:      map[string]string{"alertname": "HighLatency", "severity": "critical"},
+					Annotations: map[string]string{"summary": "p99 latency is above threshold", "description": "latency exceeded 500ms for 5m"},
+					StartsAt:    "2026-07-26T12:00:00Z",
+				},
+			},
+			GroupLabels:       map[string]string{"alertname": "HighLatency"},
+			CommonLabels:      map[string]string{"alertname": "HighLatency", "severity": "critical"},
+			CommonAnnotations: map[string]string{"summary": "p99 latency is above threshold"},
+			ExternalURL:       "https://alertmanager.example.com",
+		},
+		{
+			Case:     "firing_grouped",
+			Receiver: "team-pager",
+			Status:   "firing",
+			Alerts: Alerts{
+				{Status: "firing", Labels: map[string]string{"alertname": "DiskFull", "instance": "host-a"}, Annotations: map[string]string{"summary": "disk usage above 90%"}},
+				{Status: "firing", Labels: map[string]string{"alertname": "DiskFull", "instance": "host-b"}, Annotations: map[string]string{"summary": "disk usage above 90%"}},
+			},
+			GroupLabels:       map[string]string{"alertname": "DiskFull"},
+			CommonLabels:      map[string]string{"alertname": "DiskFull"},
+			CommonAnnotations: map[string]string{"summary": "disk usage above 90%"},
+			ExternalURL:       "https://alertmanager.example.com",
+		},
+		{
+			Case:     "resolved_single",
+			Receiver: "team-pager",
+			Status:   "resolved",
+			Alerts: Alerts{
+				{Status: "resolved", Labels: map[string]string{"alertname": "HighLatency", "severity": "critical"}, Annotations: map[string]string{"summary": "p99 latency is above threshold"}, StartsAt: "2026-07-26T12:00:00Z", EndsAt: "2026-07-26T12:10:00Z"},
+			},
+			GroupLabels:       map[string]string{"alertname": "HighLatency"},
+			CommonLabels:      map[string]string{"alertname": "HighLatency", "severity": "critical"},
+			CommonAnnotations: map[string]string{"summary": "p99 latency is above threshold"},
+			ExternalURL:       "https://alertmanager.example.com",
+		},
+		{
+			Case:     "missing_annotation",
+			Receiver: "team-pager",
+			Status:   "firing",
+			Alerts: Alerts{
+				{Status: "firing", Labels: map[string]string{"alertname": "NoSummary"}, Annotations: map[string]string{}},
+			},
+			GroupLabels:       map[string]string{"alertname": "NoSummary"},
+			CommonLabels:      map[string]string{"alertname": "NoSummary"},
+			CommonAnnotations: map[string]string{},
+			ExternalURL:       "https://alertmanager.example.com",
+		},
+	}
+}
+
+// LoadSamplePayloads reads a JSON array of SamplePayload from path, or
+// falls back to builtinPayloads when path is empty.
+func LoadSamplePayloads(path string) ([]SamplePayload, error) {
+	if path == "" {
+		return builtinPayloads(), nil
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading samples %s: %v", path, err)
+	}
+
+	var payloads []SamplePayload
+	if err := json.Unmarshal(data, &payloads); err != nil {
+		return nil, fmt.Errorf("error parsing samples %s: %v", path, err)
+	}
+	return payloads, nil
+}
+
+// RenderResult is the outcome of executing one defined template against
+// one sample payload.
+type RenderResult struct {
+	Template   string
+	Case       string
+	GoldenPath string
+	Output     string
+	Err        error
+	Diff       string
+}
+
+// Render executes every {{define}} block in v.tmpl against every payload
+// in payloads, comparing the output to its golden file under goldenDir. If
+// update is true, mismatched and missing golden files are (re)written
+// instead of reported as failures.
+func (v *TemplateValidator) Render(payloads []SamplePayload, goldenDir string, update bool) ([]RenderResult, error) {
+	if v.tmpl == nil {
+		return nil, fmt.Errorf("template %s failed to parse; nothing to render", v.FileName)
+	}
+	// validateTemplateSyntax parsed v.tmpl with alertmanagerFuncs' no-op
+	// stubs so parsing never fails on a function name alone; swap in the
+	// real implementations before executing so rendered output reflects
+	// what Alertmanager would actually send.
+	v.tmpl.Funcs(execFuncs)
+
+	var names []string
+	for _, t := range v.tmpl.Templates() {
+		if t.Tree != nil && t.Tree.Root != nil && t.Name() != "" && t.Name() != v.FileName {
+			names = append(names, t.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var results []RenderResult
+	for _, name := range names {
+		for _, payload := range payloads {
+			result := RenderResult{Template: name, Case: payload.Case}
+			result.GoldenPath = filepath.Join(goldenDir, fmt.Sprintf("%s_%s.txt", sanitizeTemplateName(name), payload.Case))
+
+			var buf bytes.Buffer
+			if err := v.tmpl.ExecuteTemplate(&buf, name, payload); err != nil {
+				result.Err = err
+				results = append(results, result)
+				continue
+			}
+			result.Output = buf.String()
+
+			golden, err := ioutil.ReadFile(result.GoldenPath)
+			switch {
+			case update:
+				if werr := ioutil.WriteFile(result.GoldenPath, buf.Bytes(), 0o644); werr != nil {
+					result.Err = werr
+				}
+			case err != nil:
+				result.Err = fmt.Errorf("golden file missing (run with -update): %s", result.GoldenPath)
+			case string(golden) != result.Output:
+				result.Err = fmt.Errorf("rendered output does not match golden file")
+				result.Diff = fmt.Sprintf("--- golden\n%s\n--- got\n%s", golden, result.Output)
+			}
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
+
+// RenderSample executes v's root template (not a {{define}} block within
+// it) against a single payload, for callers like the serve mode's
+// /validate endpoint that render one ad-hoc template against one sample
+// rather than running the full golden-file suite.
+func (v *TemplateValidator) RenderSample(payload SamplePayload) (string, error) {
+	if v.tmpl == nil {
+		return "", fmt.Errorf("template %s failed to parse; nothing to render", v.FileName)
+	}
+	// See the matching comment in Render: swap the parse-time stubs for
+	// real implementations before executing.
+	v.tmpl.Funcs(execFuncs)
+	var buf bytes.Buffer
+	if err := v.tmpl.Execute(&buf, payload); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// sanitizeTemplateName makes a template name like "slack.title" safe to
+// use as part of a golden filename.
+func sanitizeTemplateName(name string) string {
+	out := make([]rune, 0, len(name))
+	for _, r := range name {
+		if r == '/' || r == '\\' || r == ' ' {
+			out = append(out, '_')
+			continue
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+// RunRender implements the `-render` mode: parse fileName as a template,
+// execute every defined template against every sample payload, and print
+// each result against the golden files in goldenDir. It returns failed=true if any
+// render errored or mismatched its golden file (unless update is set), so
+// the caller can decide the process exit code.
+func RunRender(fileName, samplesPath, goldenDir string, update bool) (bool, error) {
+	validator, err := NewTemplateValidator(fileName)
+	if err != nil {
+		return false, err
+	}
+	validator.validateTemplateSyntax()
+	if validator.tmpl == nil {
+		return false, fmt.Errorf("template %s failed to parse", fileName)
+	}
+
+	payloads, err := LoadSamplePayloads(samplesPath)
+	if err != nil {
+		return false, err
+	}
+
+	if err := os.MkdirAll(goldenDir, 0o755); err != nil {
+		return false, err
+	}
+
+	results, err := validator.Render(payloads, goldenDir, update)
+	if err != nil {
+		return false, err
+	}
+
+	failed := false
+	for _, r := range results {
+		if r.Err != nil {
+			failed = true
+			fmt.Printf("❌ %s [%s]: %v\n", r.Template, r.Case, r.Err)
+			if r.Diff != "" {
+				fmt.Println(r.Diff)
+			}
+			continue
+		}
+		verb := "rendered"
+		if update {
+			verb = "updated"
+		}
+		fmt.Printf("✅ %s [%s] %s\n", r.Template, r.Case, verb)
+	}
+
+	return failed && !update, nil
+}
@@ -0,0 +1,184 @@
+package tmplvalidate
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metrics are the Prometheus series the serve mode exports, following the
+// same "promcertcheck as a small HTTP daemon" pattern this tool's serve
+// mode is modeled on: a counter of validation runs, a counter of errors
+// broken down by category, and a histogram of parse time.
+type metrics struct {
+	validations   *prometheus.CounterVec
+	parseErrors   *prometheus.CounterVec
+	parseDuration prometheus.Histogram
+}
+
+func newMetrics(reg prometheus.Registerer) *metrics {
+	m := &metrics{
+		validations: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "tmplvalidate_validations_total",
+			Help: "Total number of /validate requests, by outcome (ok, invalid, parse_error).",
+		}, []string{"outcome"}),
+		parseErrors: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "tmplvalidate_errors_total",
+			Help: "Total number of validation errors and warnings, by category.",
+		}, []string{"category"}),
+		parseDuration: promauto.With(reg).NewHistogram(prometheus.HistogramOpts{
+			Name:    "tmplvalidate_parse_duration_seconds",
+			Help:    "Time spent parsing and validating a template.",
+			Buckets: prometheus.DefBuckets,
+		}),
+	}
+	return m
+}
+
+// Server is the tmplvalidate HTTP daemon: POST /validate for programmatic
+// use, GET / for a small preview UI, and GET /metrics for Prometheus.
+type Server struct {
+	mux     *http.ServeMux
+	metrics *metrics
+}
+
+// NewServer builds a Server with its own Prometheus registry so embedding
+// callers don't collide with tmplvalidate's metric names in the default
+// registry.
+func NewServer() *Server {
+	reg := prometheus.NewRegistry()
+	s := &Server{
+		mux:     http.NewServeMux(),
+		metrics: newMetrics(reg),
+	}
+	s.mux.HandleFunc("/validate", s.handleValidate)
+	s.mux.HandleFunc("/", s.handleIndex)
+	s.mux.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	return s
+}
+
+// ListenAndServe starts the daemon on addr.
+func (s *Server) ListenAndServe(addr string) error {
+	return http.ListenAndServe(addr, s.mux)
+}
+
+// validateRequest is the JSON body POST /validate accepts.
+type validateRequest struct {
+	Template string         `json:"template"`
+	Sample   *SamplePayload `json:"sample,omitempty"`
+}
+
+// validateResponse is the JSON body POST /validate returns.
+type validateResponse struct {
+	Errors   []string `json:"errors"`
+	Warnings []string `json:"warnings"`
+	Rendered string   `json:"rendered,omitempty"`
+}
+
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "POST required", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req validateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid JSON body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	start := time.Now()
+	validator := NewTemplateValidatorFromContent("preview", req.Template)
+	isValid := validator.Validate()
+	s.metrics.parseDuration.Observe(time.Since(start).Seconds())
+
+	for range validator.Errors {
+		s.metrics.parseErrors.WithLabelValues("error").Inc()
+	}
+	for range validator.Warnings {
+		s.metrics.parseErrors.WithLabelValues("warning").Inc()
+	}
+
+	resp := validateResponse{Errors: validator.Errors, Warnings: validator.Warnings}
+	if isValid && req.Sample != nil {
+		rendered, err := validator.RenderSample(*req.Sample)
+		if err != nil {
+			resp.Errors = append(resp.Errors, err.Error())
+			isValid = false
+		} else {
+			resp.Rendered = rendered
+		}
+	}
+
+	outcome := "ok"
+	switch {
+	case len(validator.Errors) > 0 && validator.tmpl == nil:
+		outcome = "parse_error"
+	case !isValid:
+		outcome = "invalid"
+	}
+	s.metrics.validations.WithLabelValues(outcome).Inc()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(previewHTML))
+}
+
+// previewHTML is a minimal two-pane editor: a template textarea, a sample
+// JSON textarea, and a live preview fetched from /validate on every edit.
+const previewHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>tmplvalidate</title>
+<style>
+body { font-family: monospace; margin: 1em; }
+textarea { width: 100%; height: 200px; }
+.col { display: inline-block; width: 48%; vertical-align: top; }
+#result { white-space: pre-wrap; border: 1px solid #ccc; padding: 0.5em; min-height: 4em; }
+</style>
+</head>
+<body>
+<h1>Alertmanager template validator</h1>
+<div class="col">
+<h3>Template</h3>
+<textarea id="template">{{ define "preview" }}{{ .CommonAnnotations.summary }}{{ end }}</textarea>
+<h3>Sample (template.Data JSON, optional)</h3>
+<textarea id="sample"></textarea>
+</div>
+<div class="col">
+<h3>Result</h3>
+<div id="result"></div>
+</div>
+<script>
+async function run() {
+  const template = document.getElementById('template').value;
+  const sampleText = document.getElementById('sample').value.trim();
+  const body = { template };
+  if (sampleText) {
+    try { body.sample = JSON.parse(sampleText); }
+    catch (e) { document.getElementById('result').textContent = 'invalid sample JSON: ' + e; return; }
+  }
+  const resp = await fetch('/validate', { method: 'POST', body: JSON.stringify(body) });
+  const data = await resp.json();
+  document.getElementById('result').textContent = JSON.stringify(data, null, 2);
+}
+document.getElementById('template').addEventListener('input', run);
+document.getElementById('sample').addEventListener('input', run);
+run();
+</script>
+</body>
+</html>
+`
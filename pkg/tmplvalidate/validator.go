@@ -0,0 +1,272 @@
+// Package tmplvalidate implements the Alertmanager notification template
+// linter: parsing templates with the full Alertmanager function catalog,
+// walking the resulting parse tree for common mistakes, checking HTML
+// escaping context, resolving cross-file template references from an
+// alertmanager.yml, and rendering templates against sample alert payloads.
+// The main package at the repository root and the serve mode (serve.go)
+// are both thin wrappers around this package.
+package tmplvalidate
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"text/template"
+	"text/template/parse"
+)
+
+// alertmanagerFuncs is the catalog of functions Alertmanager's default
+// template.FuncMap exposes to notification templates, plus the handful of
+// sprig-derived helpers it re-exports. validateTemplateSyntax never calls
+// these for real; they are registered as no-op stubs purely so that
+// text/template.Parse succeeds on templates that use them, which lets us
+// walk the resulting parse.Tree instead of pattern-matching the raw text.
+// Render and RenderSample execute against the real implementations in
+// execFuncs (funcs.go) instead.
+var alertmanagerFuncs = template.FuncMap{
+	"toUpper":            func(string) string { return "" },
+	"toLower":            func(string) string { return "" },
+	"title":              func(string) string { return "" },
+	"trimSpace":          func(string) string { return "" },
+	"match":              func(string, string) (bool, error) { return false, nil },
+	"reReplaceAll":       func(string, string, string) string { return "" },
+	"safeHtml":           func(string) string { return "" },
+	"stringSlice":        func(...string) []string { return nil },
+	"join":               func(string, []string) string { return "" },
+	"sortByLabel":        func(string, interface{}) interface{} { return nil },
+	"humanize":           func(interface{}) (string, error) { return "", nil },
+	"humanize1024":       func(interface{}) (string, error) { return "", nil },
+	"humanizeDuration":   func(interface{}) (string, error) { return "", nil },
+	"humanizePercentage": func(interface{}) (string, error) { return "", nil },
+	"humanizeTimestamp":  func(interface{}) (string, error) { return "", nil },
+	// sprig-derived helpers Alertmanager also wires up
+	"urlquery": func(string) string { return "" },
+	"trunc":    func(int, string) string { return "" },
+	"date":     func(string, interface{}) string { return "" },
+	"default":  func(interface{}, interface{}) interface{} { return nil },
+	"printf":   fmt.Sprintf,
+}
+
+// TemplateValidator checks Alertmanager templates for syntax issues
+type TemplateValidator struct {
+	FileName    string
+	Content     string
+	Errors      []string
+	Warnings    []string
+	Definitions map[string]bool
+
+	tmpl *template.Template
+}
+
+// NewTemplateValidator creates a new template validator for the given file
+func NewTemplateValidator(fileName string) (*TemplateValidator, error) {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file %s: %v", fileName, err)
+	}
+
+	return NewTemplateValidatorFromContent(fileName, string(content)), nil
+}
+
+// NewTemplateValidatorFromContent creates a validator for in-memory template
+// content rather than a file on disk, for callers like the serve mode's
+// /validate endpoint that receive a template body over HTTP.
+func NewTemplateValidatorFromContent(name, content string) *TemplateValidator {
+	return &TemplateValidator{
+		FileName:    name,
+		Content:     content,
+		Errors:      []string{},
+		Warnings:    []string{},
+		Definitions: make(map[string]bool),
+	}
+}
+
+// Validate performs all validation checks
+func (v *TemplateValidator) Validate() bool {
+	v.validateTemplateSyntax()
+	if v.tmpl != nil {
+		v.validateTemplateDefinitions()
+		v.validateCommonIssues()
+	}
+	v.validateHTMLContext()
+
+	return len(v.Errors) == 0
+}
+
+// validateTemplateSyntax parses the template with the Alertmanager function
+// catalog registered so that real issues aren't masked by "function not
+// defined" errors, and real issues (unknown identifiers, bad template
+// references, unreachable branches) surface from walking the parse tree
+// instead of from brittle regex/delimiter counting.
+func (v *TemplateValidator) validateTemplateSyntax() {
+	tmpl, err := template.New(v.FileName).Funcs(alertmanagerFuncs).Parse(v.Content)
+	if err != nil {
+		v.Errors = append(v.Errors, fmt.Sprintf("Template syntax error: %v", err))
+		return
+	}
+	v.tmpl = tmpl
+}
+
+// validateTemplateDefinitions records every {{define}} block found in the
+// parsed template set and flags duplicates. Parse itself already rejects a
+// second non-empty {{define}} of the same name as a syntax error, so for a
+// set parsed by validateTemplateSyntax the duplicate branch does not fire.
+func (v *TemplateValidator) validateTemplateDefinitions() {
+	for _, t := range v.tmpl.Templates() {
+		if t.Tree == nil || t.Tree.Root == nil {
+			continue
+		}
+		name := t.Name()
+		if name == v.FileName {
+			// The implicit root template created by Parse, not a {{define}}.
+			continue
+		}
+		if v.Definitions[name] {
+			v.Errors = append(v.Errors, fmt.Sprintf("Duplicate template definition: %s", name))
+		} else {
+			v.Definitions[name] = true
+		}
+	}
+}
+
+// validateCommonIssues walks each template's parse.Tree looking for
+// undefined {{template "x"}} references, dead branches (an {{if}} whose
+// condition is a constant), and {{range}} over a field path that cannot
+// plausibly be a slice/map/array.
+func (v *TemplateValidator) validateCommonIssues() {
+	for _, t := range v.tmpl.Templates() {
+		if t.Tree == nil || t.Tree.Root == nil {
+			continue
+		}
+		v.walkNode(t.Tree.Root)
+	}
+}
+
+// walkNode recurses through a parse tree node reporting the issues
+// validateCommonIssues looks for.
+func (v *TemplateValidator) walkNode(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			v.walkNode(child)
+		}
+	case *parse.TemplateNode:
+		if !v.Definitions[n.Name] {
+			// TemplateValidator only ever sees one file, so a name it
+			// doesn't recognize may still be a legitimate reference -
+			// one of Alertmanager's built-in templates (__subject,
+			// __text_values_list, ...) or a {{define}} that lives in a
+			// sibling file. Cross-file resolution against the full set
+			// Alertmanager actually loads is ConfigValidator's job.
+			v.Warnings = append(v.Warnings, fmt.Sprintf("Template reference %q is not defined in this file", n.Name))
+		}
+		v.walkNode(n.Pipe)
+	case *parse.IfNode:
+		v.checkConstantCondition(n.Pipe, "if")
+		v.walkNode(n.List)
+		v.walkNode(n.ElseList)
+	case *parse.RangeNode:
+		v.checkRangeTarget(n.Pipe)
+		v.walkNode(n.List)
+		v.walkNode(n.ElseList)
+	case *parse.WithNode:
+		v.checkConstantCondition(n.Pipe, "with")
+		v.walkNode(n.List)
+		v.walkNode(n.ElseList)
+	}
+}
+
+// checkConstantCondition warns about an {{if}}/{{with}} whose pipeline is a
+// bare boolean or numeric literal, since that branch is always taken (or
+// never taken) and is almost always a copy-paste mistake.
+func (v *TemplateValidator) checkConstantCondition(pipe *parse.PipeNode, keyword string) {
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return
+	}
+	switch pipe.Cmds[0].Args[0].(type) {
+	case *parse.BoolNode, *parse.NumberNode:
+		v.Warnings = append(v.Warnings, fmt.Sprintf("{{%s}} condition is a constant literal and is an unreachable branch", keyword))
+	}
+}
+
+// fieldPathRegex matches a bare dotted field path such as .Alerts.Firing
+// with no pipeline functions applied, which is the shape we can reason
+// about for the range-over-non-iterable heuristic below.
+var fieldPathRegex = regexp.MustCompile(`^\.[A-Za-z0-9_.]*$`)
+
+// checkRangeTarget warns when a {{range}} pipeline is a bare field path
+// that is known to be a scalar on template.Data rather than a slice or map.
+func (v *TemplateValidator) checkRangeTarget(pipe *parse.PipeNode) {
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return
+	}
+	field, ok := pipe.Cmds[0].Args[0].(*parse.FieldNode)
+	if !ok {
+		return
+	}
+	path := "." + fieldPathString(field)
+	if !fieldPathRegex.MatchString(path) {
+		return
+	}
+	if isKnownScalarField(path) {
+		v.Warnings = append(v.Warnings, fmt.Sprintf("{{range %s}} targets a field that is not a slice or map", path))
+	}
+}
+
+func fieldPathString(field *parse.FieldNode) string {
+	result := ""
+	for i, ident := range field.Ident {
+		if i > 0 {
+			result += "."
+		}
+		result += ident
+	}
+	return result
+}
+
+// knownScalarFields lists template.Data field paths that are strings, not
+// slices or maps, so ranging over them is always a mistake.
+var knownScalarFields = map[string]bool{
+	".Receiver":    true,
+	".Status":      true,
+	".ExternalURL": true,
+}
+
+func isKnownScalarField(path string) bool {
+	return knownScalarFields[path]
+}
+
+// PrintResults prints validation results
+func (v *TemplateValidator) PrintResults() {
+	if len(v.Errors) == 0 && len(v.Warnings) == 0 {
+		fmt.Printf("✅ File %s is valid and free of common issues.\n", v.FileName)
+		return
+	}
+
+	fmt.Printf("Results for %s:\n", v.FileName)
+
+	if len(v.Errors) > 0 {
+		fmt.Println("\n❌ ERRORS:")
+		for i, err := range v.Errors {
+			fmt.Printf("%d. %s\n", i+1, err)
+		}
+	}
+
+	if len(v.Warnings) > 0 {
+		fmt.Println("\n⚠️  WARNINGS:")
+		for i, warning := range v.Warnings {
+			fmt.Printf("%d. %s\n", i+1, warning)
+		}
+	}
+
+	fmt.Println()
+	if len(v.Definitions) > 0 {
+		fmt.Println("Found template definitions:")
+		for name := range v.Definitions {
+			fmt.Printf("- %s\n", name)
+		}
+	}
+}